@@ -1,26 +1,138 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 )
 
-func main() {
-	a := gen(1, 2, 3)
-	fmt.Println(<-a)
-	fmt.Println(<-a)
-	fmt.Println(<-a)
-	// here the program breaks as there are no go routines alive
-	fmt.Println(<-a)
+// Generator emits items on a channel and always closes that channel once
+// every item has been sent or ctx is cancelled, so its goroutine never
+// hangs and receivers see the channel close once the items run out.
+func Generator[T any](ctx context.Context, items ...T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for _, item := range items {
+			select {
+			case <-ctx.Done():
+				return
+			case out <- item:
+			}
+		}
+	}()
+	return out
 }
 
-func gen(nums ...int) <-chan int {
-	out := make(chan int)
+// Map applies fn to every value received from in, forwarding results on
+// the returned channel and any errors on the returned error channel. Both
+// channels close once in is drained or ctx is cancelled.
+func Map[T, U any](ctx context.Context, in <-chan T, fn func(T) (U, error)) (<-chan U, <-chan error) {
+	out := make(chan U)
+	errs := make(chan error)
 	go func() {
-		for _, num := range nums {
-			out <- num
-			time.Sleep(5 * time.Second)
+		defer close(out)
+		defer close(errs)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-in:
+				if !ok {
+					return
+				}
+				u, err := fn(v)
+				if err != nil {
+					select {
+					case errs <- err:
+					case <-ctx.Done():
+					}
+					continue
+				}
+				select {
+				case out <- u:
+				case <-ctx.Done():
+					return
+				}
+			}
 		}
 	}()
+	return out, errs
+}
+
+// FanIn merges multiple channels into one, closing the output once every
+// input channel has closed or ctx is cancelled.
+func FanIn[T any](ctx context.Context, chans ...<-chan T) <-chan T {
+	out := make(chan T)
+	var wg sync.WaitGroup
+	wg.Add(len(chans))
+
+	for _, c := range chans {
+		go func(c <-chan T) {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case v, ok := <-c:
+					if !ok {
+						return
+					}
+					select {
+					case out <- v:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}(c)
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
 	return out
 }
+
+// drainErrors collects every error sent on errs into a single error joined
+// via errors.Join, returning nil if none arrived before the channel closed.
+func drainErrors(errs <-chan error) error {
+	var all []error
+	for err := range errs {
+		all = append(all, err)
+	}
+	return errors.Join(all...)
+}
+
+func main() {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	nums := Generator(ctx, 1, 2, 3, 4, 5)
+	doubled, errs := Map(ctx, nums, func(n int) (int, error) {
+		if n == 3 {
+			return 0, fmt.Errorf("cannot process %d", n)
+		}
+		return n * 2, nil
+	})
+
+	var errsDone sync.WaitGroup
+	errsDone.Add(1)
+	var pipelineErr error
+	go func() {
+		defer errsDone.Done()
+		pipelineErr = drainErrors(errs)
+	}()
+
+	for v := range doubled {
+		fmt.Println(v)
+	}
+	errsDone.Wait()
+
+	if pipelineErr != nil {
+		fmt.Printf("pipeline finished with errors: %v\n", pipelineErr)
+	}
+}
@@ -0,0 +1,206 @@
+// Command lintimports statically enforces two of the repo's error-handling
+// hygiene rules: no file may import a package the config marks as
+// forbidden (for example github.com/pkg/errors, in favor of the stdlib
+// errors package), and no fmt.Errorf call may format an error with %v
+// where %w is needed to keep it in the error chain — the exact %v-vs-%w
+// distinction the error-handling chapter's section 11 calls out. A call
+// can opt out of the %v/%w check with a trailing "lintimports:allow"
+// comment, which is how section 11's own intentional %v counter-example
+// stays in the tree without tripping the gate it motivates.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Config is the blocklist loaded from a JSON file.
+type Config struct {
+	ForbiddenImports []ForbiddenImport `json:"forbiddenImports"`
+}
+
+// ForbiddenImport is one blocklisted import path and the reason it's
+// banned, surfaced in the tool's output.
+type ForbiddenImport struct {
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+// violation records a single rule broken by a file: either a forbidden
+// import or an errors-chain-breaking fmt.Errorf call.
+type violation struct {
+	file   string
+	pkg    string
+	rule   string
+	detail string
+}
+
+// allowDirective suppresses the %v/%w check for a single fmt.Errorf call
+// when placed in a trailing comment on that call's line, e.g.
+//
+//	fmt.Errorf("failed to find user: %v", err) // lintimports:allow intentional %v counter-example
+//
+// This is how the chapter's own worked examples of the %v-vs-%w anti-pattern
+// (error-handling/main.go's wrappingWithoutW) stay in the tree without
+// tripping the gate they exist to motivate.
+const allowDirective = "lintimports:allow"
+
+// allowedLines returns the set of source lines carrying an allowDirective
+// comment in file.
+func allowedLines(fset *token.FileSet, file *ast.File) map[int]bool {
+	allowed := make(map[int]bool)
+	for _, group := range file.Comments {
+		for _, c := range group.List {
+			if strings.Contains(c.Text, allowDirective) {
+				allowed[fset.Position(c.Pos()).Line] = true
+			}
+		}
+	}
+	return allowed
+}
+
+func main() {
+	configPath := flag.String("config", "lintimports.json", "path to the blocklist config (JSON)")
+	root := flag.String("root", ".", "root directory to scan for .go files")
+	flag.Parse()
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "lintimports: %v\n", err)
+		os.Exit(2)
+	}
+
+	violations, err := lint(*root, cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "lintimports: %v\n", err)
+		os.Exit(2)
+	}
+
+	for _, v := range violations {
+		fmt.Printf("%s: package %s: %s: %s\n", v.file, v.pkg, v.rule, v.detail)
+	}
+	if len(violations) > 0 {
+		os.Exit(1)
+	}
+}
+
+func loadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config %s: %w", path, err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing config %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// lint walks every .go file under root and reports a violation for each
+// forbidden import and each %v-wrapping fmt.Errorf call it finds.
+func lint(root string, cfg *Config) ([]violation, error) {
+	var violations []violation
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return fmt.Errorf("parsing %s: %w", path, err)
+		}
+
+		violations = append(violations, checkForbiddenImports(path, file, cfg)...)
+		violations = append(violations, checkErrorfWrapping(path, fset, file)...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return violations, nil
+}
+
+func checkForbiddenImports(path string, file *ast.File, cfg *Config) []violation {
+	var violations []violation
+	for _, imp := range file.Imports {
+		importPath := strings.Trim(imp.Path.Value, `"`)
+		for _, forbidden := range cfg.ForbiddenImports {
+			if importPath == forbidden.Path {
+				violations = append(violations, violation{
+					file:   path,
+					pkg:    file.Name.Name,
+					rule:   "forbidden import " + importPath,
+					detail: forbidden.Reason,
+				})
+			}
+		}
+	}
+	return violations
+}
+
+// checkErrorfWrapping flags fmt.Errorf calls whose format string contains
+// %v but not %w. That pattern is how section 11 demonstrates losing the
+// error chain: %v stringifies the wrapped error instead of preserving it
+// for errors.Is/errors.As. A call on a line carrying an allowDirective
+// comment is skipped, so intentional %v counter-examples don't trip the
+// gate they exist to motivate.
+func checkErrorfWrapping(path string, fset *token.FileSet, file *ast.File) []violation {
+	var violations []violation
+	allowed := allowedLines(fset, file)
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		pkgIdent, ok := sel.X.(*ast.Ident)
+		if !ok || pkgIdent.Name != "fmt" || sel.Sel.Name != "Errorf" {
+			return true
+		}
+		if allowed[fset.Position(call.Pos()).Line] {
+			return true
+		}
+		if len(call.Args) == 0 {
+			return true
+		}
+		lit, ok := call.Args[0].(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return true
+		}
+		format, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			return true
+		}
+		if strings.Contains(format, "%v") && !strings.Contains(format, "%w") {
+			violations = append(violations, violation{
+				file:   path,
+				pkg:    file.Name.Name,
+				rule:   "fmt.Errorf wraps with %v instead of %w",
+				detail: fmt.Sprintf("format %q breaks errors.Is/errors.As on the wrapped error", format),
+			})
+		}
+		return true
+	})
+
+	return violations
+}
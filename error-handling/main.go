@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"runtime"
 	"strconv"
+	"strings"
 )
 
 // ==============================================================================
@@ -292,7 +294,7 @@ func wrappingWithoutW() {
 	baseErr := ErrNotFound
 
 	// Using %v instead of %w - does NOT preserve error chain
-	wrappedWithV := fmt.Errorf("failed to find user: %v", baseErr)
+	wrappedWithV := fmt.Errorf("failed to find user: %v", baseErr) // lintimports:allow intentional %v counter-example for section 11
 
 	// Using %w - preserves error chain
 	wrappedWithW := fmt.Errorf("failed to find user: %w", baseErr)
@@ -511,6 +513,175 @@ func errorAggregation() {
 	fmt.Printf("    Aggregated errors: %v\n\n", err)
 }
 
+// ==============================================================================
+// 21. MULTI-ERROR TREE TRAVERSAL (WalkErrorTree and FindAll)
+// ==============================================================================
+
+// inspectErrorChain only follows the single-parent Unwrap() error
+// convention, so it silently truncates trees built with errors.Join or a
+// type like MultiError that implements Unwrap() []error (Go 1.20+).
+// WalkErrorTree performs a pre-order, depth-first traversal that follows
+// both conventions and visits every node in the tree, not just the first
+// chain it finds.
+func WalkErrorTree(err error, visit func(depth int, err error)) {
+	var walk func(err error, depth int)
+	walk = func(err error, depth int) {
+		if err == nil {
+			return
+		}
+		visit(depth, err)
+
+		switch x := err.(type) {
+		case interface{ Unwrap() []error }:
+			for _, child := range x.Unwrap() {
+				walk(child, depth+1)
+			}
+		case interface{ Unwrap() error }:
+			walk(x.Unwrap(), depth+1)
+		}
+	}
+	walk(err, 0)
+}
+
+// FindAll returns every error of type T anywhere in err's tree, in the
+// order WalkErrorTree visits them. Unlike errors.As, which stops at the
+// first match, FindAll keeps going and collects them all.
+func FindAll[T error](err error) []T {
+	var matches []T
+	WalkErrorTree(err, func(_ int, e error) {
+		if t, ok := e.(T); ok {
+			matches = append(matches, t)
+		}
+	})
+	return matches
+}
+
+// nodeSummary describes a single error tree node for printing. errors.Join's
+// result has an Error() string that concatenates every descendant's message
+// with embedded newlines, which reads as a garbled blob at its own depth; so
+// for any node that implements Unwrap() []error (errors.Join's result, or
+// MultiError) print the type and child count instead and let the
+// traversal print the children themselves, one per line.
+func nodeSummary(err error) string {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return fmt.Sprintf("%T (%d wrapped errors)", err, len(joined.Unwrap()))
+	}
+	return fmt.Sprintf("%v", err)
+}
+
+func errorTreeTraversalDemo() {
+	tree := errors.Join(
+		fmt.Errorf("request failed: %w", &HTTPError{
+			StatusCode: 502,
+			Method:     "GET",
+			URL:        "/api/orders",
+			Err:        ErrTimeout,
+		}),
+		&MultiError{
+			Errors: []error{
+				&ValidationError{Field: "email", Value: "", Message: "required"},
+				&ValidationError{Field: "age", Value: -1, Message: "must be positive"},
+			},
+		},
+	)
+
+	fmt.Printf("21. Error tree traversal (WalkErrorTree vs inspectErrorChain):\n")
+	WalkErrorTree(tree, func(depth int, err error) {
+		fmt.Printf("    %s[%d] %s (type: %T)\n", strings.Repeat("  ", depth), depth, nodeSummary(err), err)
+	})
+
+	validationErrs := FindAll[*ValidationError](tree)
+	fmt.Printf("    FindAll[*ValidationError] found %d match(es):\n", len(validationErrs))
+	for _, v := range validationErrs {
+		fmt.Printf("      - field %q: %s\n", v.Field, v.Message)
+	}
+	fmt.Println()
+}
+
+// ==============================================================================
+// 22. STRUCTURED ERRORS WITH STACK TRACES (AppError)
+// ==============================================================================
+
+// AppError is a structured alternative to fmt.Errorf("%w", ...): it carries
+// a machine-readable Code (for mapping onto HTTP status codes or gRPC
+// codes, the way HTTPError does today), a human-readable Message, an
+// optional wrapped Cause, and the call stack captured at the point it was
+// created.
+type AppError struct {
+	Code    string
+	Message string
+	Cause   error
+	Stack   []uintptr
+}
+
+// callers captures the stack above the AppError constructor that invoked
+// it, skipping this function and that constructor's own frame.
+func callers() []uintptr {
+	var pcs [32]uintptr
+	n := runtime.Callers(3, pcs[:])
+	return pcs[:n]
+}
+
+// New creates an AppError with no cause, capturing the current stack.
+func New(code, msg string) *AppError {
+	return &AppError{Code: code, Message: msg, Stack: callers()}
+}
+
+// Wrap creates an AppError around an existing error, capturing the current
+// stack at the point of wrapping.
+func Wrap(err error, code, msg string) *AppError {
+	return &AppError{Code: code, Message: msg, Cause: err, Stack: callers()}
+}
+
+func (e *AppError) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("[%s] %s: %v", e.Code, e.Message, e.Cause)
+	}
+	return fmt.Sprintf("[%s] %s", e.Code, e.Message)
+}
+
+func (e *AppError) Unwrap() error {
+	return e.Cause
+}
+
+// Format implements fmt.Formatter so %v and %s print the compact message
+// while %+v additionally appends the stack captured at construction,
+// resolved into function/file/line via runtime.CallersFrames.
+func (e *AppError) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		io.WriteString(f, e.Error())
+		if !f.Flag('+') {
+			return
+		}
+		frames := runtime.CallersFrames(e.Stack)
+		for {
+			frame, more := frames.Next()
+			fmt.Fprintf(f, "\n\t%s\n\t\t%s:%d", frame.Function, frame.File, frame.Line)
+			if !more {
+				break
+			}
+		}
+	case 's':
+		io.WriteString(f, e.Error())
+	}
+}
+
+func appErrorDemo() {
+	fmt.Printf("22. Structured errors with stack traces (AppError):\n")
+
+	cause := errors.New("connection refused")
+	err := Wrap(cause, "ERR_DB_UNAVAILABLE", "failed to reach database")
+
+	fmt.Printf("    %%v:  %v\n", err)
+	fmt.Printf("    %%+v:%+v\n\n", err)
+
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		fmt.Printf("    errors.As found AppError, Code: %s\n\n", appErr.Code)
+	}
+}
+
 // ==============================================================================
 // MAIN FUNCTION - Demonstrates all error features
 // ==============================================================================
@@ -544,6 +715,8 @@ func main() {
 	complexErrorChain()
 	deferErrorExample()
 	errorAggregation()
+	errorTreeTraversalDemo()
+	appErrorDemo()
 
 	fmt.Println("=== Summary of Key Concepts ===")
 	fmt.Println("1. Basic creation: errors.New(), fmt.Errorf()")
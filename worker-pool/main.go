@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Pool is a bounded worker pool: a fixed number of goroutines pull tasks
+// off a buffered channel and run them, while their errors are collected
+// for the caller to inspect once every task has finished.
+type Pool struct {
+	workers   int
+	queueSize int
+	ctx       context.Context
+	cancel    context.CancelFunc
+
+	tasks     chan func() error
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+
+	closeMu sync.RWMutex
+	closed  bool
+
+	mu   sync.Mutex
+	errs []error
+}
+
+// Option configures a Pool, following the same functional-options pattern
+// as NewServer/WithConfigA.
+type Option func(*Pool)
+
+// WithWorkers overrides the worker count passed to NewPool.
+func WithWorkers(n int) Option {
+	return func(p *Pool) {
+		p.workers = n
+	}
+}
+
+// WithQueueSize sets the task queue's buffer size. Submit blocks once the
+// queue is full. Defaults to the number of workers.
+func WithQueueSize(n int) Option {
+	return func(p *Pool) {
+		p.queueSize = n
+	}
+}
+
+// WithContext lets the caller supply a context whose cancellation stops the
+// pool from accepting or running further work. Defaults to
+// context.Background().
+func WithContext(ctx context.Context) Option {
+	return func(p *Pool) {
+		p.ctx, p.cancel = context.WithCancel(ctx)
+	}
+}
+
+// NewPool creates a Pool with the given number of workers and starts them
+// immediately.
+func NewPool(workers int, opts ...Option) *Pool {
+	p := &Pool{workers: workers}
+	for _, opt := range opts {
+		opt(p)
+	}
+	if p.queueSize == 0 {
+		p.queueSize = p.workers
+	}
+	if p.ctx == nil {
+		p.ctx, p.cancel = context.WithCancel(context.Background())
+	}
+
+	p.tasks = make(chan func() error, p.queueSize)
+	p.wg.Add(p.workers)
+	for i := 0; i < p.workers; i++ {
+		go p.worker()
+	}
+	return p
+}
+
+func (p *Pool) worker() {
+	defer p.wg.Done()
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case task, ok := <-p.tasks:
+			if !ok || p.ctx.Err() != nil {
+				return
+			}
+			if err := task(); err != nil {
+				p.mu.Lock()
+				p.errs = append(p.errs, err)
+				p.mu.Unlock()
+			}
+		}
+	}
+}
+
+// Submit enqueues a task to be run by a worker. It blocks while the queue
+// is full and returns a "pool closed" error instead of enqueuing if the
+// pool's context is done or Wait has already closed the task queue. The
+// closed check and the send happen under closeMu's read lock, which Wait
+// excludes with its write lock before closing the channel, so a producer
+// goroutine can safely call Submit concurrently with another goroutine
+// calling Wait without racing on the channel close.
+func (p *Pool) Submit(task func() error) error {
+	p.closeMu.RLock()
+	defer p.closeMu.RUnlock()
+
+	if p.closed || p.ctx.Err() != nil {
+		return errors.New("worker-pool: submit on closed pool")
+	}
+
+	select {
+	case <-p.ctx.Done():
+		return errors.New("worker-pool: submit on closed pool")
+	case p.tasks <- task:
+		return nil
+	}
+}
+
+// Wait marks the pool closed and closes the task queue exactly once,
+// blocks until every worker has drained it, and returns the collected
+// task errors joined via errors.Join so callers can use errors.Is/errors.As
+// on the aggregate. It returns nil if no task failed.
+func (p *Pool) Wait() error {
+	p.closeOnce.Do(func() {
+		p.closeMu.Lock()
+		p.closed = true
+		close(p.tasks)
+		p.closeMu.Unlock()
+	})
+	p.wg.Wait()
+	if p.cancel != nil {
+		p.cancel()
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return errors.Join(p.errs...)
+}
+
+func main() {
+	pool := NewPool(3, WithQueueSize(10))
+
+	for i := 0; i < 6; i++ {
+		i := i
+		if err := pool.Submit(func() error {
+			if i%2 == 0 {
+				return fmt.Errorf("task %d failed", i)
+			}
+			fmt.Printf("task %d succeeded\n", i)
+			return nil
+		}); err != nil {
+			fmt.Printf("submit %d rejected: %v\n", i, err)
+		}
+	}
+
+	if err := pool.Wait(); err != nil {
+		fmt.Printf("pool finished with errors:\n%v\n", err)
+	} else {
+		fmt.Println("pool finished with no errors")
+	}
+
+	demoConcurrentSubmitWait()
+}
+
+// demoConcurrentSubmitWait exercises the safety property Submit and Wait
+// document: a producer goroutine may keep calling Submit while another
+// goroutine calls Wait, without panicking or racing on the task queue's
+// close. It's a separate pool and demo from the one above, which exists
+// to show the pool actually running tasks; this one only proves Submit
+// degrades to a clean "pool closed" error once Wait has started closing
+// the queue, however the two goroutines happen to interleave.
+func demoConcurrentSubmitWait() {
+	pool := NewPool(3, WithQueueSize(10))
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 6; i++ {
+			i := i
+			if err := pool.Submit(func() error {
+				fmt.Printf("concurrent task %d ran\n", i)
+				return nil
+			}); err != nil {
+				fmt.Printf("concurrent submit %d rejected: %v\n", i, err)
+			}
+		}
+	}()
+
+	if err := pool.Wait(); err != nil {
+		fmt.Printf("concurrent demo finished with errors:\n%v\n", err)
+	} else {
+		fmt.Println("concurrent demo finished with no errors")
+	}
+	wg.Wait()
+}